@@ -0,0 +1,123 @@
+// Package dimacs reads and writes the standard DIMACS CNF format used by the
+// SATLIB/SAT-Competition benchmark suites, so that dpll.Formula values can be
+// loaded from and exported to the wider SAT tooling ecosystem.
+package dimacs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Aki0x137/concurrent-sat-solver-go/dpll"
+)
+
+// Parse reads a DIMACS CNF formula from r.
+//
+// It accepts comment lines starting with "c", a single "p cnf <nvars>
+// <nclauses>" header, and zero-terminated clauses whose literals may be
+// split across multiple lines. Tautological clauses (containing both a
+// literal and its negation) are dropped and duplicate literals within a
+// clause are deduped. It is an error for a literal's absolute value to
+// exceed the variable count declared in the header.
+func Parse(r io.Reader) (dpll.Formula, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	var nVars, nClauses int
+	headerSeen := false
+	var formula dpll.Formula
+	var current dpll.Clause
+	seenClauses := 0
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "c") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "p") {
+			fields := strings.Fields(line)
+			if len(fields) != 4 || fields[1] != "cnf" {
+				return nil, fmt.Errorf("dimacs: malformed header %q", line)
+			}
+			var err error
+			if nVars, err = strconv.Atoi(fields[2]); err != nil {
+				return nil, fmt.Errorf("dimacs: invalid variable count: %w", err)
+			}
+			if nClauses, err = strconv.Atoi(fields[3]); err != nil {
+				return nil, fmt.Errorf("dimacs: invalid clause count: %w", err)
+			}
+			headerSeen = true
+			continue
+		}
+
+		if !headerSeen {
+			return nil, errors.New("dimacs: clause data before \"p cnf\" header")
+		}
+
+		for _, tok := range strings.Fields(line) {
+			lit, err := strconv.Atoi(tok)
+			if err != nil {
+				return nil, fmt.Errorf("dimacs: invalid literal %q: %w", tok, err)
+			}
+
+			if lit == 0 {
+				seenClauses++
+				if clause, ok := normalizeClause(current); ok {
+					formula = append(formula, clause)
+				}
+				current = nil
+				continue
+			}
+
+			if abs(lit) > nVars {
+				return nil, fmt.Errorf("dimacs: literal %d exceeds declared variable count %d", lit, nVars)
+			}
+			current = append(current, dpll.Literal(lit))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("dimacs: %w", err)
+	}
+	if len(current) > 0 {
+		return nil, errors.New("dimacs: clause missing terminating 0")
+	}
+	if seenClauses != nClauses {
+		return nil, fmt.Errorf("dimacs: header declares %d clauses, found %d", nClauses, seenClauses)
+	}
+
+	return formula, nil
+}
+
+// normalizeClause dedupes literals within clause and reports ok=false if the
+// clause is a tautology (it contains both a literal and its negation), in
+// which case it should be dropped from the formula.
+func normalizeClause(clause dpll.Clause) (deduped dpll.Clause, ok bool) {
+	seen := make(map[dpll.Literal]bool, len(clause))
+	for _, lit := range clause {
+		if seen[lit] {
+			continue
+		}
+		if seen[-lit] {
+			return nil, false
+		}
+		seen[lit] = true
+		deduped = append(deduped, lit)
+	}
+	return deduped, true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Write emits formula to w in DIMACS CNF format.
+func Write(w io.Writer, formula dpll.Formula) error {
+	return formula.WriteDIMACS(w)
+}
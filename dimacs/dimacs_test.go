@@ -0,0 +1,105 @@
+package dimacs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Aki0x137/concurrent-sat-solver-go/dpll"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    dpll.Formula
+		wantErr bool
+	}{
+		{
+			name:  "comments and a clause split across lines",
+			input: "c a comment\np cnf 3 1\nc another comment\n1 -2\n3 0\n",
+			want:  dpll.Formula{{1, -2, 3}},
+		},
+		{
+			name:  "tautology dropped, clause count matches header",
+			input: "p cnf 2 2\n1 -1 0\n1 2 0\n",
+			want:  dpll.Formula{{1, 2}},
+		},
+		{
+			name:  "duplicate literals deduped",
+			input: "p cnf 1 1\n1 1 0\n",
+			want:  dpll.Formula{{1}},
+		},
+		{
+			name:    "literal exceeds declared variable count",
+			input:   "p cnf 1 1\n2 0\n",
+			wantErr: true,
+		},
+		{
+			name:    "clause missing terminating 0",
+			input:   "p cnf 1 1\n1",
+			wantErr: true,
+		},
+		{
+			name:    "malformed header",
+			input:   "p cnf 1\n1 0\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse() returned nil error, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() returned error %v, want nil", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Parse() = %v, want %v", got, tt.want)
+			}
+			for i, clause := range got {
+				if len(clause) != len(tt.want[i]) {
+					t.Fatalf("clause %d = %v, want %v", i, clause, tt.want[i])
+				}
+				for j, lit := range clause {
+					if lit != tt.want[i][j] {
+						t.Fatalf("clause %d = %v, want %v", i, clause, tt.want[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriteParseRoundTrip(t *testing.T) {
+	formula := dpll.Formula{{1, -2, 3}, {-1, 2}, {3}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, formula); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	if len(got) != len(formula) {
+		t.Fatalf("round-trip = %v, want %v", got, formula)
+	}
+	for i, clause := range got {
+		if len(clause) != len(formula[i]) {
+			t.Fatalf("clause %d = %v, want %v", i, clause, formula[i])
+		}
+		for j, lit := range clause {
+			if lit != formula[i][j] {
+				t.Fatalf("clause %d = %v, want %v", i, clause, formula[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package dpll
+
+import "math"
+
+// Brancher picks the next variable (and polarity) to branch on during
+// search. The solver keeps it informed of assignments and learned clauses
+// so that stateful heuristics (like VSIDS) can stay in sync with the trail.
+type Brancher interface {
+	// Decide returns the next branching literal. ok is false once every
+	// variable known to the brancher is assigned.
+	Decide() (literal Literal, ok bool)
+
+	// Assigned is called whenever the solver assigns variable, whether by
+	// decision or propagation.
+	Assigned(variable Literal)
+
+	// Unassigned is called whenever the solver backtracks past variable.
+	Unassigned(variable Literal)
+
+	// Learn is called with each newly learned clause, for branchers that
+	// bump activity on the literals it contains.
+	Learn(clause Clause)
+}
+
+// vsidsBrancher implements the VSIDS (Variable State Independent Decaying
+// Sum) heuristic: every literal has an activity score, bumped when it
+// appears in a learned clause and globally decayed after every conflict.
+// Unassigned variables are kept in a max-heap keyed by the larger of their
+// two literals' activity, giving an O(log n) pick-max.
+type vsidsBrancher struct {
+	activity map[Literal]float64
+	bumpInc  float64
+	decay    float64
+
+	heap []Literal
+	pos  map[Literal]int // variable -> index in heap
+}
+
+// NewVSIDSBrancher returns a Brancher implementing VSIDS, seeded from the
+// variables appearing in formula.
+func NewVSIDSBrancher(formula Formula) Brancher {
+	b := &vsidsBrancher{
+		activity: make(map[Literal]float64),
+		bumpInc:  1.0,
+		decay:    0.95,
+		pos:      make(map[Literal]int),
+	}
+
+	seen := make(map[Literal]bool)
+	for _, clause := range formula {
+		for _, literal := range clause {
+			v := varOf(literal)
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			b.push(v)
+		}
+	}
+
+	return b
+}
+
+func (b *vsidsBrancher) score(variable Literal) float64 {
+	return math.Max(b.activity[variable], b.activity[-variable])
+}
+
+func (b *vsidsBrancher) less(i, j int) bool {
+	return b.score(b.heap[i]) > b.score(b.heap[j])
+}
+
+func (b *vsidsBrancher) swap(i, j int) {
+	b.heap[i], b.heap[j] = b.heap[j], b.heap[i]
+	b.pos[b.heap[i]] = i
+	b.pos[b.heap[j]] = j
+}
+
+func (b *vsidsBrancher) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !b.less(i, parent) {
+			return
+		}
+		b.swap(i, parent)
+		i = parent
+	}
+}
+
+func (b *vsidsBrancher) siftDown(i int) {
+	n := len(b.heap)
+	for {
+		left, right, top := 2*i+1, 2*i+2, i
+		if left < n && b.less(left, top) {
+			top = left
+		}
+		if right < n && b.less(right, top) {
+			top = right
+		}
+		if top == i {
+			return
+		}
+		b.swap(i, top)
+		i = top
+	}
+}
+
+func (b *vsidsBrancher) push(variable Literal) {
+	if _, ok := b.pos[variable]; ok {
+		return
+	}
+	b.heap = append(b.heap, variable)
+	b.pos[variable] = len(b.heap) - 1
+	b.siftUp(len(b.heap) - 1)
+}
+
+func (b *vsidsBrancher) remove(variable Literal) {
+	i, ok := b.pos[variable]
+	if !ok {
+		return
+	}
+
+	last := len(b.heap) - 1
+	if i != last {
+		b.swap(i, last)
+	}
+	b.heap = b.heap[:last]
+	delete(b.pos, variable)
+	if i < len(b.heap) {
+		b.siftDown(i)
+		b.siftUp(i)
+	}
+}
+
+func (b *vsidsBrancher) Decide() (Literal, bool) {
+	if len(b.heap) == 0 {
+		return 0, false
+	}
+	variable := b.heap[0]
+	b.remove(variable)
+
+	if b.activity[-variable] > b.activity[variable] {
+		return -variable, true
+	}
+	return variable, true
+}
+
+func (b *vsidsBrancher) Assigned(variable Literal) {
+	b.remove(variable)
+}
+
+func (b *vsidsBrancher) Unassigned(variable Literal) {
+	b.push(variable)
+}
+
+// Learn bumps the activity of every literal in clause, then decays every
+// activity score. Decay is implemented as a plain multiplication since
+// activity bumps happen once per conflict at most, which keeps this O(n)
+// pass cheap relative to propagation.
+func (b *vsidsBrancher) Learn(clause Clause) {
+	for _, literal := range clause {
+		b.activity[literal] += b.bumpInc
+		if i, ok := b.pos[varOf(literal)]; ok {
+			b.siftUp(i)
+		}
+	}
+	for literal := range b.activity {
+		b.activity[literal] *= b.decay
+	}
+}
+
+// jeroslowWangBrancher implements the (one-sided) Jeroslow-Wang heuristic: a
+// static score per literal, sum(2^-|clause|) over clauses containing it,
+// computed once from the original formula and never updated.
+type jeroslowWangBrancher struct {
+	score      map[Literal]float64
+	unassigned map[Literal]bool
+}
+
+// NewJeroslowWangBrancher returns a Brancher implementing the Jeroslow-Wang
+// heuristic over formula.
+func NewJeroslowWangBrancher(formula Formula) Brancher {
+	b := &jeroslowWangBrancher{
+		score:      make(map[Literal]float64),
+		unassigned: make(map[Literal]bool),
+	}
+
+	for _, clause := range formula {
+		weight := math.Pow(2, -float64(len(clause)))
+		for _, literal := range clause {
+			b.score[literal] += weight
+			b.unassigned[varOf(literal)] = true
+		}
+	}
+
+	return b
+}
+
+func (b *jeroslowWangBrancher) Decide() (Literal, bool) {
+	best := Literal(0)
+	bestScore := -1.0
+
+	for variable := range b.unassigned {
+		literal := variable
+		score := b.score[variable]
+		if b.score[-variable] > score {
+			literal, score = -variable, b.score[-variable]
+		}
+		if score > bestScore {
+			best, bestScore = literal, score
+		}
+	}
+
+	if bestScore < 0 {
+		return 0, false
+	}
+	return best, true
+}
+
+func (b *jeroslowWangBrancher) Assigned(variable Literal) {
+	delete(b.unassigned, variable)
+}
+
+func (b *jeroslowWangBrancher) Unassigned(variable Literal) {
+	b.unassigned[variable] = true
+}
+
+func (b *jeroslowWangBrancher) Learn(Clause) {
+	// Jeroslow-Wang scores are static, computed once from the original
+	// formula; learned clauses don't change them.
+}
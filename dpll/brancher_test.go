@@ -0,0 +1,75 @@
+package dpll
+
+import "testing"
+
+// checkHeapInvariant verifies the max-heap property and that pos agrees with
+// the heap slice for every entry still tracked.
+func checkHeapInvariant(t *testing.T, b *vsidsBrancher) {
+	t.Helper()
+	for i := range b.heap {
+		left, right := 2*i+1, 2*i+2
+		if left < len(b.heap) && b.less(left, i) {
+			t.Fatalf("heap invariant violated: heap[%d]=%v scores below child heap[%d]=%v", i, b.heap[i], left, b.heap[left])
+		}
+		if right < len(b.heap) && b.less(right, i) {
+			t.Fatalf("heap invariant violated: heap[%d]=%v scores below child heap[%d]=%v", i, b.heap[i], right, b.heap[right])
+		}
+	}
+	for variable, i := range b.pos {
+		if i < 0 || i >= len(b.heap) || b.heap[i] != variable {
+			t.Fatalf("pos[%v]=%d inconsistent with heap=%v", variable, i, b.heap)
+		}
+	}
+}
+
+func TestVSIDSBrancherPushRemove(t *testing.T) {
+	formula := Formula{{1, 2, 3}, {4, 5}}
+	b := NewVSIDSBrancher(formula).(*vsidsBrancher)
+	checkHeapInvariant(t, b)
+
+	// Removing a variable that isn't the last heap slot must not corrupt
+	// pos for the variable swapped into its place.
+	b.remove(1)
+	checkHeapInvariant(t, b)
+	if _, ok := b.pos[1]; ok {
+		t.Fatalf("pos[1] still present after remove")
+	}
+
+	b.remove(3)
+	checkHeapInvariant(t, b)
+
+	b.push(1)
+	checkHeapInvariant(t, b)
+	if _, ok := b.pos[1]; !ok {
+		t.Fatalf("pos[1] missing after push")
+	}
+
+	for _, v := range []Literal{2, 4, 5, 1} {
+		b.remove(v)
+		checkHeapInvariant(t, b)
+	}
+	if len(b.heap) != 0 {
+		t.Fatalf("heap = %v, want empty after removing every variable", b.heap)
+	}
+}
+
+func TestVSIDSBrancherDecideDrainsHeap(t *testing.T) {
+	formula := Formula{{1, 2}, {2, 3}, {3, 4}, {4, 5}}
+	b := NewVSIDSBrancher(formula)
+
+	seen := make(map[Literal]bool)
+	for {
+		literal, ok := b.Decide()
+		if !ok {
+			break
+		}
+		v := varOf(literal)
+		if seen[v] {
+			t.Fatalf("variable %v decided twice", v)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 5 {
+		t.Fatalf("decided %d variables, want 5", len(seen))
+	}
+}
@@ -0,0 +1,284 @@
+package dpll
+
+import (
+	"io"
+	"maps"
+	"sort"
+)
+
+// CDCLOptions configures SolveCDCL. A zero value is valid: each field falls
+// back to a sane default.
+type CDCLOptions struct {
+	// RestartUnit scales the Luby restart sequence: the solver restarts
+	// after RestartUnit * luby(n) conflicts since the last restart.
+	// Defaults to 100.
+	RestartUnit int
+
+	// ReduceInterval is the number of learned clauses between clause
+	// database reductions. Defaults to 2000.
+	ReduceInterval int
+
+	// Brancher picks the decision literal at every branch point. Defaults
+	// to the first-unassigned-literal strategy used by plain DPLL.
+	Brancher Brancher
+
+	// PhaseSaving, when true, re-uses each variable's most recent assigned
+	// value as its polarity the next time it's branched on, instead of
+	// whatever polarity the Brancher picked.
+	PhaseSaving bool
+
+	// ProofWriter, if set, receives a DRAT proof as the search progresses:
+	// every learned clause is written as its literals followed by a
+	// terminating 0, every clause deleted by reduceDB as "d <literals> 0",
+	// and, if the formula turns out UNSAT, a closing empty clause.
+	ProofWriter io.Writer
+}
+
+func (o CDCLOptions) withDefaults() CDCLOptions {
+	if o.RestartUnit <= 0 {
+		o.RestartUnit = 100
+	}
+	if o.ReduceInterval <= 0 {
+		o.ReduceInterval = 2000
+	}
+	return o
+}
+
+// SolveCDCL solves formula with conflict-driven clause learning: on every
+// conflict it derives the first-unique-implication-point clause, backjumps
+// to the second-highest decision level in that clause, and asserts its UIP
+// literal. It periodically reduces the learned clause database by LBD and
+// restarts the search on a Luby sequence.
+func SolveCDCL(formula Formula, opts CDCLOptions) (bool, Assignment) {
+	if !checkClauseValidity(formula) {
+		return false, nil
+	}
+	opts = opts.withDefaults()
+
+	s := NewSolver(formula)
+	if opts.Brancher != nil {
+		s.SetBrancher(opts.Brancher)
+	}
+
+	sat, assignment, _ := s.runCDCL(opts, 0, nil)
+	return sat, assignment
+}
+
+// cdclHooks lets a caller observe and influence the CDCL loop from the
+// outside, which is what SolvePortfolio uses to check for cancellation and
+// to import/export learned clauses between workers. A nil *cdclHooks means
+// "run to completion with no outside interference".
+type cdclHooks struct {
+	cancelled func() bool
+	shareLBD  int
+	broadcast func(Clause)
+	importFn  func(*Solver)
+}
+
+// runCDCL is the CDCL search loop shared by SolveCDCL, SolvePortfolio and
+// IncrementalSolver. baseLevel is the decision level below which the search
+// never backtracks or restarts, which lets IncrementalSolver hold its
+// assumptions on the trail across restarts; it is always 0 outside of that.
+// A conflict that can't be resolved above baseLevel is reported as UNSAT;
+// the DRAT closing clause is only written for baseLevel 0, since a
+// conflict rooted in baseLevel > 0 is a property of the assumptions
+// IncrementalSolver pushed, not a refutation of the underlying formula. It
+// returns completed=false if hooks reported cancellation before the search
+// reached a verdict, in which case sat and assignment are meaningless.
+func (s *Solver) runCDCL(opts CDCLOptions, baseLevel int, hooks *cdclHooks) (sat bool, assignment Assignment, completed bool) {
+	if s.loadConflict || s.propagate() != -1 {
+		if baseLevel == 0 {
+			writeProofRefutation(opts.ProofWriter)
+		}
+		return false, nil, true
+	}
+
+	learnedSinceReduce := 0
+	conflictsSinceRestart := 0
+	lubyIndex := 1
+
+	for {
+		if hooks != nil {
+			if hooks.cancelled() {
+				return false, nil, false
+			}
+			if hooks.importFn != nil {
+				hooks.importFn(s)
+			}
+		}
+
+		literal, ok := s.pickLiteral()
+		if !ok {
+			return true, maps.Clone(s.assignment), true
+		}
+		if opts.PhaseSaving {
+			if saved, known := s.phase[varOf(literal)]; known {
+				literal = varOf(literal)
+				if !saved {
+					literal = -literal
+				}
+			}
+		}
+		s.decide(literal)
+
+		for conflict := s.propagate(); conflict != -1; {
+			if s.decisionLvl <= baseLevel {
+				if baseLevel == 0 {
+					writeProofRefutation(opts.ProofWriter)
+				}
+				return false, nil, true
+			}
+
+			learnt, backjumpLevel := s.analyze(conflict)
+			if backjumpLevel < baseLevel {
+				backjumpLevel = baseLevel
+			}
+			lbd := s.lbdOf(learnt)
+
+			s.undoTo(s.positionAtLevel(backjumpLevel))
+
+			clauseIdx := s.addClause(learnt)
+			s.clauseLBD[clauseIdx] = lbd
+			s.assign(learnt[0], clauseIdx)
+			if s.brancher != nil {
+				s.brancher.Learn(learnt)
+			}
+			if hooks != nil && hooks.broadcast != nil && lbd < hooks.shareLBD {
+				hooks.broadcast(learnt)
+			}
+			if opts.ProofWriter != nil {
+				writeDRATClause(opts.ProofWriter, learnt)
+			}
+
+			learnedSinceReduce++
+			if learnedSinceReduce >= opts.ReduceInterval {
+				s.reduceDB(opts.ProofWriter)
+				learnedSinceReduce = 0
+			}
+
+			// Propagate the asserting literal before even considering a
+			// restart: undoTo clears the pending queue, so restarting
+			// first would silently drop the literal's consequences
+			// instead of letting them surface as a conflict (handled by
+			// looping back around) or further propagation.
+			conflict = s.propagate()
+
+			conflictsSinceRestart++
+			if conflict == -1 && conflictsSinceRestart >= luby(lubyIndex)*opts.RestartUnit {
+				s.undoTo(s.positionAtLevel(baseLevel))
+				conflictsSinceRestart = 0
+				lubyIndex++
+			}
+		}
+	}
+}
+
+// analyze derives the 1-UIP learned clause for the clause that conflicted,
+// by resolving backwards from it against the reasons of literals at the
+// current decision level until exactly one such literal remains. It returns
+// the learned clause (with the asserting UIP literal in position 0) and the
+// level to backjump to (0 if the clause is unit).
+func (s *Solver) analyze(conflict int) (Clause, int) {
+	seen := make(map[Literal]bool)
+	learnt := Clause{0}
+	counter := 0
+	var p Literal
+	reason := conflict
+	idx := len(s.trail) - 1
+
+	for {
+		for _, q := range s.clauses[reason] {
+			if q == p {
+				continue
+			}
+			v := varOf(q)
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			if s.varLevel[v] == s.decisionLvl {
+				counter++
+			} else if s.varLevel[v] > 0 {
+				learnt = append(learnt, q)
+			}
+		}
+
+		for !seen[varOf(s.trail[idx].literal)] {
+			idx--
+		}
+		p = s.trail[idx].literal
+		reason = s.varReason[varOf(p)]
+		seen[varOf(p)] = false
+		counter--
+		idx--
+
+		if counter == 0 {
+			break
+		}
+	}
+	learnt[0] = -p
+
+	backjumpLevel := 0
+	for _, literal := range learnt[1:] {
+		if level := s.varLevel[varOf(literal)]; level > backjumpLevel {
+			backjumpLevel = level
+		}
+	}
+
+	return learnt, backjumpLevel
+}
+
+// lbdOf returns the literal block distance of clause: the number of
+// distinct decision levels among its literals' variables.
+func (s *Solver) lbdOf(clause Clause) int {
+	levels := make(map[int]bool, len(clause))
+	for _, literal := range clause {
+		levels[s.varLevel[varOf(literal)]] = true
+	}
+	return len(levels)
+}
+
+// reduceDB deletes the worse (higher-LBD) half of the learned clauses that
+// are not currently locked (i.e. not the reason for some assigned literal).
+// Original, non-learned clauses are never touched. If proofWriter is set,
+// each deleted clause is recorded as a DRAT deletion line.
+func (s *Solver) reduceDB(proofWriter io.Writer) {
+	locked := make(map[int]bool)
+	for _, reason := range s.varReason {
+		if reason != noReason {
+			locked[reason] = true
+		}
+	}
+
+	var candidates []int
+	for i := s.numOriginal; i < len(s.clauses); i++ {
+		if s.deleted[i] || locked[i] {
+			continue
+		}
+		candidates = append(candidates, i)
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		return s.clauseLBD[candidates[a]] < s.clauseLBD[candidates[b]]
+	})
+
+	for _, clauseIdx := range candidates[len(candidates)/2:] {
+		s.deleted[clauseIdx] = true
+		if proofWriter != nil {
+			writeDRATDeletion(proofWriter, s.clauses[clauseIdx])
+		}
+	}
+}
+
+// luby returns the i-th (1-indexed) term of the Luby sequence
+// 1, 1, 2, 1, 1, 2, 4, 1, 1, 2, 1, 1, 2, 4, 8, ..., used to size the gap
+// between restarts.
+func luby(i int) int {
+	k := 1
+	for (1<<k)-1 < i {
+		k++
+	}
+	if i == (1<<k)-1 {
+		return 1 << (k - 1)
+	}
+	return luby(i - (1<<(k-1)) + 1)
+}
@@ -3,12 +3,8 @@ package dpll
 import (
 	"errors"
 	"fmt"
-	"maps"
+	"io"
 	"math"
-	"slices"
-	"sync"
-
-	"github.com/Aki0x137/concurrent-sat-solver-go/set"
 )
 
 type Literal int
@@ -17,12 +13,6 @@ type Formula []Clause
 
 type Assignment map[Literal]bool
 
-type Result struct {
-	mu         *sync.RWMutex
-	satisfied  bool
-	assignment Assignment
-}
-
 // checkClauseValidity validates the formula
 func checkClauseValidity(formula Formula) bool {
 	for _, clause := range formula {
@@ -67,225 +57,69 @@ func isSatisfied(formula Formula, assignment Assignment) bool {
 	return true
 }
 
-// unitPropagate performs unit propagation on formula, based on curent assignments
-func unitPropagate(formula Formula, assignment Assignment) (Formula, Assignment) {
-	updatedFormula := slices.Clone(formula)
-	updatedAssignment := maps.Clone(assignment)
-	for {
-		var unitClauses []Clause
-		for _, clause := range updatedFormula {
-			if len(clause) == 1 {
-				unitClauses = append(unitClauses, clause)
-			}
-		}
-
-		if len(unitClauses) == 0 {
-			break
-		}
-
-		for _, clause := range unitClauses {
-			literal := clause[0]
-			absLiteral := math.Abs(float64(literal))
-			updatedAssignment[Literal(absLiteral)] = literal > 0
-
-			var filteredFormula Formula
-			for _, c := range updatedFormula {
-				if !slices.Contains(c, literal) {
-					filteredFormula = append(filteredFormula, c)
-				}
-			}
-
-			var simplifiedFormula Formula
-			for _, c := range filteredFormula {
-				updatedClause := slices.Clone(c)
-				if index := slices.Index(updatedClause, -literal); index >= 0 {
-					updatedClause = slices.Delete(updatedClause, index, index+1)
-				}
-				simplifiedFormula = append(simplifiedFormula, updatedClause)
+// WriteDIMACS writes formula to w in DIMACS CNF format (a "p cnf <vars>
+// <clauses>" header followed by the zero-terminated clauses). The variable
+// count is derived from the largest literal magnitude appearing in formula.
+func (formula Formula) WriteDIMACS(w io.Writer) error {
+	nVars := 0
+	for _, clause := range formula {
+		for _, literal := range clause {
+			if v := int(math.Abs(float64(literal))); v > nVars {
+				nVars = v
 			}
-			updatedFormula = simplifiedFormula
 		}
 	}
 
-	return updatedFormula, updatedAssignment
-}
-
-/*
-pureLiteralAssignment checks for pure literals and updates formula.
-
-If a propositional variable occurs with only one polarity in the formula, it is called pure. A pure literal can always be assigned in a way that makes all clauses containing it true. Thus, when it is assigned in such a way, these clauses do not constrain the search anymore, and can be deleted.
-*/
-func pureLiteralAssignment(formula Formula, assignment Assignment) (Formula, Assignment) {
-	updatedFormula := slices.Clone(formula)
-	updatedAssignment := maps.Clone(assignment)
-
-	allLiteralsSet := set.NewSet[Literal]()
-	for _, clauses := range formula {
-		for _, literal := range clauses {
-			allLiteralsSet.Add(literal)
-		}
+	if _, err := fmt.Fprintf(w, "p cnf %d %d\n", nVars, len(formula)); err != nil {
+		return err
 	}
 
-	allLiterals := allLiteralsSet.Values()
-	pureLiterals := set.NewSet[Literal]()
-	for _, literal := range allLiterals {
-		if !slices.Contains(allLiterals, -literal) {
-			pureLiterals.Add(literal)
-		}
-	}
-
-	for _, literal := range pureLiterals.Values() {
-		absLiteral := math.Abs(float64(literal))
-		updatedAssignment[Literal(absLiteral)] = literal > 0
-
-		var filteredFormula Formula
-		for _, clause := range updatedFormula {
-			if index := slices.Index(clause, literal); index == -1 {
-				filteredFormula = append(filteredFormula, clause)
-			}
-		}
-		updatedFormula = filteredFormula
-	}
-
-	return updatedFormula, updatedAssignment
-}
-
-// simplifyFormula removes truthy clauses and removes redundant literals after an assignment
-func simplifyFormula(formula Formula, literal Literal) Formula {
-	var simplifiedFormula Formula
 	for _, clause := range formula {
-		if !slices.Contains(clause, literal) {
-			updatedClause := slices.Clone(clause)
-			if index := slices.Index(updatedClause, -literal); index >= 0 {
-				updatedClause = slices.Delete(updatedClause, index, index+1)
+		for _, literal := range clause {
+			if _, err := fmt.Fprintf(w, "%d ", literal); err != nil {
+				return err
 			}
-			simplifiedFormula = append(simplifiedFormula, updatedClause)
+		}
+		if _, err := fmt.Fprintln(w, "0"); err != nil {
+			return err
 		}
 	}
-	return simplifiedFormula
+
+	return nil
 }
 
+// Solve runs the DPLL search procedure over formula, starting from assignment.
+// It is a thin wrapper around Solver, which performs propagation with a
+// two-watched-literals index instead of rescanning the whole formula.
 func Solve(formula Formula, assignment Assignment) (bool, Assignment) {
-	if len(formula) == 0 {
-		return false, assignment
-	}
-
 	if !checkClauseValidity(formula) {
 		return false, assignment
 	}
 
-	if isSatisfied(formula, assignment) {
-		return true, assignment
-	}
-
-	newFormula, newAssignment := unitPropagate(formula, assignment)
-
-	newFormula, newAssignment = pureLiteralAssignment(newFormula, newAssignment)
-
-	if isSatisfied(newFormula, newAssignment) {
-		return true, newAssignment
-	}
-
-	if !checkClauseValidity(formula) {
+	solver := NewSolver(formula)
+	if solver.loadConflict {
 		return false, assignment
 	}
-
-	selectedLiteral, err := selectLiteral(newFormula, newAssignment)
-	if err != nil {
-		return false, assignment
+	for variable, value := range assignment {
+		literal := variable
+		if !value {
+			literal = -variable
+		}
+		if solver.isFalse(literal) {
+			return false, assignment
+		}
+		if !solver.isTrue(literal) {
+			solver.decide(literal)
+		}
 	}
 
-	ans := Result{&sync.RWMutex{}, false, Assignment{}}
-	var wg sync.WaitGroup
-
-	assignment1 := maps.Clone(newAssignment)
-	assignment1[selectedLiteral] = true
-
-	simplifiedFormula := simplifyFormula(newFormula, selectedLiteral)
-
-	wg.Add(1)
-	go recursiveSolution(simplifiedFormula, assignment1, &wg, &ans)
-
-	assignment2 := maps.Clone(newAssignment)
-	assignment2[selectedLiteral] = false
-
-	simplifiedFormula = simplifyFormula(newFormula, -selectedLiteral)
-
-	wg.Add(1)
-	go recursiveSolution(simplifiedFormula, assignment2, &wg, &ans)
-
-	wg.Wait()
-
-	fmt.Println("After wait")
-
-	if ans.satisfied {
-		return true, ans.assignment
-	} else {
+	if solver.propagate() != -1 {
 		return false, assignment
 	}
-}
-
-func recursiveSolution(formula Formula, assignment Assignment, wg *sync.WaitGroup, ans *Result) {
-	defer wg.Done()
 
-	fmt.Println("INside recursive", assignment)
-
-	ans.mu.RLock()
-	if ans.satisfied {
-		return
+	if satisfied, result := solver.search(); satisfied {
+		return true, result
 	}
-	ans.mu.RUnlock()
-
-	if len(formula) == 0 {
-		return
-	}
-
-	if !checkClauseValidity(formula) {
-		return
-	}
-
-	if isSatisfied(formula, assignment) {
-		ans.mu.Lock()
-		ans.assignment = assignment
-		ans.satisfied = true
-		ans.mu.Unlock()
-		return
-	}
-
-	newFormula, newAssignment := unitPropagate(formula, assignment)
-
-	newFormula, newAssignment = pureLiteralAssignment(newFormula, newAssignment)
-
-	if isSatisfied(newFormula, newAssignment) {
-		ans.mu.Lock()
-		ans.assignment = newAssignment
-		ans.satisfied = true
-		ans.mu.Unlock()
-		return
-	}
-
-	if !checkClauseValidity(formula) {
-		return
-	}
-
-	selectedLiteral, err := selectLiteral(newFormula, newAssignment)
-	if err != nil {
-		return
-	}
-
-	assignment1 := maps.Clone(newAssignment)
-	assignment1[selectedLiteral] = true
-
-	simplifiedFormula := simplifyFormula(newFormula, selectedLiteral)
-
-	wg.Add(1)
-	go recursiveSolution(simplifiedFormula, assignment1, wg, ans)
-
-	assignment2 := maps.Clone(newAssignment)
-	assignment2[selectedLiteral] = false
-
-	simplifiedFormula = simplifyFormula(newFormula, -selectedLiteral)
 
-	wg.Add(1)
-	go recursiveSolution(simplifiedFormula, assignment2, wg, ans)
+	return false, assignment
 }
@@ -0,0 +1,114 @@
+package dpll
+
+import "context"
+
+// IncrementalSolver is an IPASIR-style incremental SAT solver: clauses can be
+// added and assumptions pushed between Solve calls, and the clause database
+// (including everything learned so far) is kept across them instead of being
+// rebuilt from scratch each time.
+type IncrementalSolver struct {
+	solver      *Solver
+	opts        CDCLOptions
+	assumptions []Literal
+
+	unsat bool // set once a clause falsified at level 0 is added, or a Solve with no assumptions fails
+
+	failed []Literal
+}
+
+// NewIncrementalSolver returns an empty IncrementalSolver configured by opts.
+func NewIncrementalSolver(opts CDCLOptions) *IncrementalSolver {
+	opts = opts.withDefaults()
+
+	s := NewSolver(nil)
+	if opts.Brancher != nil {
+		s.SetBrancher(opts.Brancher)
+	}
+
+	return &IncrementalSolver{solver: s, opts: opts}
+}
+
+// AddClause permanently adds clause to the formula. It first backs the
+// solver up to decision level 0, dropping whatever a prior successful Solve
+// left on the trail. If clause is falsified by the level-0 assignment, the
+// instance is permanently unsatisfiable and every subsequent Solve call
+// returns false without running search.
+func (is *IncrementalSolver) AddClause(clause Clause) {
+	if is.unsat {
+		return
+	}
+	is.solver.undoTo(is.solver.positionAtLevel(0))
+	if _, conflict := is.solver.addUnderAssignment(clause); conflict {
+		is.unsat = true
+	}
+}
+
+// Assume pushes lits as assumptions for the next call to Solve only; every
+// Solve call clears the assumptions it ran with, whether it succeeds or
+// fails.
+func (is *IncrementalSolver) Assume(lits ...Literal) {
+	is.assumptions = append(is.assumptions, lits...)
+}
+
+// Solve runs CDCL search under the current assumptions, returning once a
+// verdict is reached or ctx is done. If the result is false and assumptions
+// were pushed for this call, FailedAssumptions reports every one of them:
+// the set is not minimized to the assumptions involved in the conflict, and
+// it is reported even when the formula is unsatisfiable on its own.
+func (is *IncrementalSolver) Solve(ctx context.Context) (sat bool, assignment Assignment, err error) {
+	assumptions := is.assumptions
+	is.assumptions = nil
+	is.failed = nil
+
+	if is.unsat {
+		return false, nil, nil
+	}
+
+	s := is.solver
+	// A prior successful Solve leaves its assumption decisions (and
+	// whatever search assigned on top of them) on the trail; only the
+	// clause database is meant to persist across calls, so back up to
+	// level 0 before laying down this call's assumptions.
+	s.undoTo(s.positionAtLevel(0))
+	base := len(s.trail)
+
+	for _, literal := range assumptions {
+		if s.isFalse(literal) {
+			s.undoTo(base)
+			is.failed = assumptions
+			return false, nil, nil
+		}
+		if !s.isTrue(literal) {
+			s.decide(literal)
+		}
+	}
+
+	hooks := &cdclHooks{cancelled: func() bool { return ctx.Err() != nil }}
+	sat, assignment, completed := s.runCDCL(is.opts, s.decisionLvl, hooks)
+	if !completed {
+		s.undoTo(base)
+		return false, nil, ctx.Err()
+	}
+	if !sat {
+		if len(assumptions) > 0 {
+			is.failed = assumptions
+		} else {
+			is.unsat = true
+		}
+		s.undoTo(base)
+	}
+	return sat, assignment, nil
+}
+
+// FailedAssumptions returns every assumption pushed for the most recent
+// Solve call if that call returned false with assumptions in place, or nil
+// if it succeeded or had no assumptions. It does not tell whether the
+// formula is unsatisfiable without them.
+func (is *IncrementalSolver) FailedAssumptions() []Literal {
+	if is.failed == nil {
+		return nil
+	}
+	out := make([]Literal, len(is.failed))
+	copy(out, is.failed)
+	return out
+}
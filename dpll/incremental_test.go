@@ -0,0 +1,110 @@
+package dpll
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestIncrementalSolverAccumulatesClauses(t *testing.T) {
+	formula := Formula{{1, 2}, {-1, 3}}
+	is := NewIncrementalSolver(CDCLOptions{})
+	for _, clause := range formula {
+		is.AddClause(clause)
+	}
+
+	sat, assignment, err := is.Solve(context.Background())
+	if err != nil || !sat {
+		t.Fatalf("Solve() = (%v, err=%v), want sat", sat, err)
+	}
+	if err := VerifyAssignment(formula, assignment); err != nil {
+		t.Fatalf("VerifyAssignment() = %v, want nil", err)
+	}
+}
+
+func TestIncrementalSolverFailedAssumptions(t *testing.T) {
+	is := NewIncrementalSolver(CDCLOptions{})
+	is.AddClause(Clause{1, 2})
+
+	is.Assume(-1, -2)
+	sat, _, err := is.Solve(context.Background())
+	if err != nil || sat {
+		t.Fatalf("Solve() = (%v, err=%v), want unsat", sat, err)
+	}
+	if failed := is.FailedAssumptions(); len(failed) != 2 {
+		t.Fatalf("FailedAssumptions() = %v, want the 2 pushed assumptions", failed)
+	}
+
+	// The assumptions from the failed call must not have stuck around: a
+	// fresh Solve with no assumptions should succeed on the same instance.
+	sat, assignment, err := is.Solve(context.Background())
+	if err != nil || !sat {
+		t.Fatalf("Solve() = (%v, err=%v), want sat", sat, err)
+	}
+	if err := VerifyAssignment(Formula{{1, 2}}, assignment); err != nil {
+		t.Fatalf("VerifyAssignment() = %v, want nil", err)
+	}
+}
+
+func TestIncrementalSolverUnwindsAssumptionsAfterSuccess(t *testing.T) {
+	// {1,2},{2,3},{-1,-3} is satisfiable with 1=false. A prior successful
+	// Solve under Assume(1) must not leave that decision (or anything
+	// implied from it) on the trail, or the next call's Assume(-1) would
+	// be checked against a stale assignment instead of the bare clause
+	// database.
+	formula := Formula{{1, 2}, {2, 3}, {-1, -3}}
+	is := NewIncrementalSolver(CDCLOptions{})
+	for _, clause := range formula {
+		is.AddClause(clause)
+	}
+
+	is.Assume(1)
+	sat, _, err := is.Solve(context.Background())
+	if err != nil || !sat {
+		t.Fatalf("Solve() = (%v, err=%v), want sat", sat, err)
+	}
+
+	is.Assume(-1)
+	sat, assignment, err := is.Solve(context.Background())
+	if err != nil || !sat {
+		t.Fatalf("Solve() = (%v, err=%v), want sat", sat, err)
+	}
+	if err := VerifyAssignment(formula, assignment); err != nil {
+		t.Fatalf("VerifyAssignment() = %v, want nil", err)
+	}
+	if assignment[1] {
+		t.Fatalf("assignment[1] = true, want false per Assume(-1)")
+	}
+}
+
+func TestIncrementalSolverAddClauseDedupesRepeatedLiteral(t *testing.T) {
+	// {2, 2} is really the unit clause {2}; AddClause, like NewSolver,
+	// takes a raw, caller-supplied Clause with no dedup already done
+	// upstream (unlike the dimacs reader), so it must not skip recognizing
+	// the duplicate as unit.
+	is := NewIncrementalSolver(CDCLOptions{})
+	is.AddClause(Clause{2, 2})
+	is.AddClause(Clause{-2})
+
+	sat, _, err := is.Solve(context.Background())
+	if err != nil || sat {
+		t.Fatalf("Solve() = (%v, err=%v), want unsat: {2,2} and {-2} contradict", sat, err)
+	}
+}
+
+func TestIncrementalSolverAssumptionConflictDoesNotWriteProof(t *testing.T) {
+	is := NewIncrementalSolver(CDCLOptions{})
+	is.AddClause(Clause{1, 2})
+
+	var proof bytes.Buffer
+	is.opts.ProofWriter = &proof
+
+	is.Assume(-1, -2)
+	sat, _, err := is.Solve(context.Background())
+	if err != nil || sat {
+		t.Fatalf("Solve() = (%v, err=%v), want unsat", sat, err)
+	}
+	if proof.Len() != 0 {
+		t.Fatalf("proof = %q, want empty: an infeasible assumption set doesn't refute the formula", proof.String())
+	}
+}
@@ -0,0 +1,147 @@
+package dpll
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// PortfolioOptions configures SolvePortfolio. A zero value is valid: each
+// field falls back to a sane default.
+type PortfolioOptions struct {
+	// Workers is the number of concurrent CDCL searches to run. Defaults to
+	// GOMAXPROCS.
+	Workers int
+
+	// ShareLBD is the LBD threshold below which a worker's learned clauses
+	// are published to its peers. Defaults to 5. Set to a negative value
+	// to disable clause sharing entirely.
+	ShareLBD int
+
+	// Seed drives the per-worker configuration (branching heuristic,
+	// restart pacing, phase-saving) so repeated runs are reproducible.
+	Seed int64
+}
+
+func (o PortfolioOptions) withDefaults() PortfolioOptions {
+	if o.Workers <= 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+	if o.ShareLBD == 0 {
+		o.ShareLBD = 5
+	}
+	return o
+}
+
+// workerConfig draws one worker's CDCL configuration from rng: a randomly
+// chosen branching heuristic, restart pacing, reduction interval and
+// phase-saving setting, so the pool behaves like a portfolio rather than N
+// copies of the same search.
+func workerConfig(rng *rand.Rand, formula Formula) CDCLOptions {
+	cfg := CDCLOptions{
+		RestartUnit:    50 + rng.Intn(200),
+		ReduceInterval: 1000 + rng.Intn(2000),
+		PhaseSaving:    rng.Intn(2) == 0,
+	}
+	if rng.Intn(2) == 0 {
+		cfg.Brancher = NewVSIDSBrancher(formula)
+	} else {
+		cfg.Brancher = NewJeroslowWangBrancher(formula)
+	}
+	return cfg
+}
+
+// SolvePortfolio solves formula with a fixed pool of workers (size
+// opts.Workers), each running a full sequential CDCL search under a
+// different configuration. The first worker to reach a verdict (SAT or
+// UNSAT) wins and the rest are cancelled via ctx. Learned clauses with an
+// LBD below opts.ShareLBD are sent to the other workers as they are found.
+//
+// err is non-nil (ctx.Err()) if ctx was done before any worker reached a
+// verdict; sat and assignment are then meaningless.
+func SolvePortfolio(ctx context.Context, formula Formula, opts PortfolioOptions) (sat bool, assignment Assignment, err error) {
+	if !checkClauseValidity(formula) {
+		return false, nil, nil
+	}
+	opts = opts.withDefaults()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type verdict struct {
+		sat        bool
+		assignment Assignment
+	}
+	results := make(chan verdict, opts.Workers)
+
+	inboxes := make([]chan Clause, opts.Workers)
+	for i := range inboxes {
+		inboxes[i] = make(chan Clause, 256)
+	}
+	broadcast := func(from int, clause Clause) {
+		for i, inbox := range inboxes {
+			if i == from {
+				continue
+			}
+			select {
+			case inbox <- clause:
+			default: // peer's inbox is full; drop rather than stall the search
+			}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		cfg := workerConfig(rng, formula)
+
+		wg.Add(1)
+		go func(id int, cfg CDCLOptions) {
+			defer wg.Done()
+
+			s := NewSolver(formula)
+			if cfg.Brancher != nil {
+				s.SetBrancher(cfg.Brancher)
+			}
+
+			hooks := &cdclHooks{
+				cancelled: func() bool { return ctx.Err() != nil },
+				shareLBD:  opts.ShareLBD,
+				broadcast: func(clause Clause) { broadcast(id, clause) },
+				importFn: func(s *Solver) {
+					for {
+						select {
+						case clause := <-inboxes[id]:
+							s.ImportClause(clause)
+						default:
+							return
+						}
+					}
+				},
+			}
+
+			sat, assignment, completed := s.runCDCL(cfg, 0, hooks)
+			if !completed {
+				return
+			}
+
+			select {
+			case results <- verdict{sat, assignment}:
+				cancel()
+			default:
+			}
+		}(i, cfg)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	if v, ok := <-results; ok {
+		return v.sat, v.assignment, nil
+	}
+	return false, nil, ctx.Err()
+}
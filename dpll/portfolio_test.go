@@ -0,0 +1,45 @@
+package dpll
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSolvePortfolioSatisfiable(t *testing.T) {
+	formula := Formula{{1, 2}, {-1, 2}, {1, -2}}
+
+	sat, assignment, err := SolvePortfolio(context.Background(), formula, PortfolioOptions{Workers: 4, Seed: 1})
+	if err != nil {
+		t.Fatalf("SolvePortfolio() returned error: %v", err)
+	}
+	if !sat {
+		t.Fatalf("SolvePortfolio() = unsat, want sat")
+	}
+	if err := VerifyAssignment(formula, assignment); err != nil {
+		t.Fatalf("VerifyAssignment() = %v, want nil", err)
+	}
+}
+
+func TestSolvePortfolioUnsatisfiable(t *testing.T) {
+	formula := Formula{{1}, {-1}}
+
+	sat, _, err := SolvePortfolio(context.Background(), formula, PortfolioOptions{Workers: 4, Seed: 1})
+	if err != nil {
+		t.Fatalf("SolvePortfolio() returned error: %v", err)
+	}
+	if sat {
+		t.Fatalf("SolvePortfolio() = sat, want unsat")
+	}
+}
+
+func TestSolvePortfolioCancelled(t *testing.T) {
+	formula := Formula{{1, 2}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := SolvePortfolio(ctx, formula, PortfolioOptions{Workers: 2, Seed: 1})
+	if err == nil {
+		t.Fatalf("SolvePortfolio() with a cancelled context returned nil error")
+	}
+}
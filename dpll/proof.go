@@ -0,0 +1,55 @@
+package dpll
+
+import (
+	"fmt"
+	"io"
+)
+
+// writeDRATClause writes clause as a DRAT addition line: its literals
+// followed by a terminating 0.
+func writeDRATClause(w io.Writer, clause Clause) error {
+	for _, literal := range clause {
+		if _, err := fmt.Fprintf(w, "%d ", literal); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "0")
+	return err
+}
+
+// writeDRATDeletion writes clause as a DRAT deletion line ("d " followed by
+// its literals and a terminating 0).
+func writeDRATDeletion(w io.Writer, clause Clause) error {
+	if _, err := fmt.Fprint(w, "d "); err != nil {
+		return err
+	}
+	return writeDRATClause(w, clause)
+}
+
+// writeProofRefutation writes the closing empty clause that marks a DRAT
+// proof as a refutation, if w is set.
+func writeProofRefutation(w io.Writer) {
+	if w == nil {
+		return
+	}
+	fmt.Fprintln(w, "0")
+}
+
+// VerifyAssignment checks that assignment satisfies every clause of
+// formula, returning nil if it does, or an error identifying the first
+// clause it doesn't.
+func VerifyAssignment(formula Formula, assignment Assignment) error {
+	for i, clause := range formula {
+		satisfied := false
+		for _, literal := range clause {
+			if value, ok := assignment[varOf(literal)]; ok && value == (literal > 0) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			return fmt.Errorf("dpll: clause %d %v is not satisfied by assignment", i, clause)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package dpll
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSolveCDCLWritesRefutationProof(t *testing.T) {
+	formula := Formula{{1}, {-1}}
+
+	var proof bytes.Buffer
+	sat, _ := SolveCDCL(formula, CDCLOptions{ProofWriter: &proof})
+	if sat {
+		t.Fatalf("SolveCDCL() = sat, want unsat")
+	}
+
+	lines := strings.Split(strings.TrimSpace(proof.String()), "\n")
+	if lines[len(lines)-1] != "0" {
+		t.Fatalf("proof does not end in the closing empty clause: %q", proof.String())
+	}
+}
+
+func TestSolveCDCLNoProofWhenSatisfiable(t *testing.T) {
+	formula := Formula{{1, 2}}
+
+	var proof bytes.Buffer
+	sat, _ := SolveCDCL(formula, CDCLOptions{ProofWriter: &proof})
+	if !sat {
+		t.Fatalf("SolveCDCL() = unsat, want sat")
+	}
+	if proof.Len() != 0 {
+		t.Fatalf("proof = %q, want empty for a satisfiable formula with no conflicts", proof.String())
+	}
+}
+
+func TestVerifyAssignment(t *testing.T) {
+	formula := Formula{{1, 2}, {-1, 3}}
+
+	ok := Assignment{1: true, 2: false, 3: true}
+	if err := VerifyAssignment(formula, ok); err != nil {
+		t.Fatalf("VerifyAssignment(%v) = %v, want nil", ok, err)
+	}
+
+	bad := Assignment{1: false, 2: false, 3: false}
+	if err := VerifyAssignment(formula, bad); err == nil {
+		t.Fatalf("VerifyAssignment(%v) = nil, want an error", bad)
+	}
+}
@@ -0,0 +1,363 @@
+package dpll
+
+import "maps"
+
+// noReason marks a trail literal that was a decision (or otherwise has no
+// antecedent clause), as opposed to one implied by unit propagation.
+const noReason = -1
+
+// trailEntry records one assigned literal, in assignment order, so that
+// backtracking can unwind the assignment and the propagation queue together.
+type trailEntry struct {
+	literal Literal
+	level   int
+}
+
+// Solver holds a clause database plus a two-watched-literals index: each
+// clause keeps exactly two non-falsified literals under watch, and watchers
+// maps a literal to the clauses currently watching it. Assigning a literal
+// false only walks the watch list of that literal instead of rescanning the
+// whole formula, which is what made the original unitPropagate O(clauses x
+// literals) per round.
+//
+// The solver also tracks, per variable, the decision level it was assigned
+// at and the clause that implied it (if any), which CDCL conflict analysis
+// needs on top of the propagation machinery.
+type Solver struct {
+	clauses   []Clause
+	deleted   []bool
+	clauseLBD []int
+	watchIdx  [][2]int
+	watchers  map[Literal][]int
+
+	numOriginal int
+
+	assignment Assignment
+	varLevel   map[Literal]int
+	varReason  map[Literal]int
+
+	trail       []trailEntry
+	queue       []Literal
+	decisionLvl int
+
+	brancher Brancher
+	phase    map[Literal]bool
+
+	// loadConflict is set if two of the formula's original clauses conflict
+	// under level-0 assertion (e.g. unit clauses on opposite polarities of
+	// the same variable), since such a clause is dropped by
+	// addUnderAssignment before it can be propagated into.
+	loadConflict bool
+}
+
+// SetBrancher installs b as the solver's decision heuristic. Every literal
+// already on the trail (such as the unit clauses NewSolver asserts at load
+// time) is reported to b as assigned, so that b's view matches the trail.
+func (s *Solver) SetBrancher(b Brancher) {
+	s.brancher = b
+	for _, entry := range s.trail {
+		b.Assigned(varOf(entry.literal))
+	}
+}
+
+// pickLiteral returns the next branching literal, deferring to the
+// installed Brancher if any, or falling back to selectLiteral otherwise.
+func (s *Solver) pickLiteral() (Literal, bool) {
+	if s.brancher != nil {
+		return s.brancher.Decide()
+	}
+	literal, err := selectLiteral(s.clauses, s.assignment)
+	return literal, err == nil
+}
+
+// NewSolver builds a Solver over formula, seeding the watch lists with the
+// first two literals of every clause that aren't already falsified by units
+// asserted from earlier clauses (or the single such literal, watched twice).
+// Clauses that are unit at that point are asserted immediately instead of
+// waiting for search to rediscover them; a clause that is already entirely
+// falsified (e.g. the second of two conflicting units) sets loadConflict.
+func NewSolver(formula Formula) *Solver {
+	s := &Solver{
+		watchers:   make(map[Literal][]int),
+		assignment: make(Assignment),
+		varLevel:   make(map[Literal]int),
+		varReason:  make(map[Literal]int),
+		phase:      make(map[Literal]bool),
+	}
+
+	for _, clause := range formula {
+		if _, conflict := s.addUnderAssignment(clause); conflict {
+			s.loadConflict = true
+		}
+	}
+	s.numOriginal = len(s.clauses)
+
+	return s
+}
+
+// registerClause appends clause to the database, watching the literals at
+// indices w0 and w1 (which may be equal, for a unit clause), and returns its
+// index.
+func (s *Solver) registerClause(clause Clause, w0, w1 int) int {
+	idx := len(s.clauses)
+	s.clauses = append(s.clauses, clause)
+	s.deleted = append(s.deleted, false)
+	s.clauseLBD = append(s.clauseLBD, 0)
+
+	s.watchIdx = append(s.watchIdx, [2]int{w0, w1})
+	s.watchers[clause[w0]] = append(s.watchers[clause[w0]], idx)
+	if w1 != w0 {
+		s.watchers[clause[w1]] = append(s.watchers[clause[w1]], idx)
+	}
+
+	return idx
+}
+
+// addClause appends clause to the database, watching its first two literals
+// (or its single literal twice, if it's a unit clause).
+func (s *Solver) addClause(clause Clause) int {
+	clause = dedupeLiterals(clause)
+	w1 := 0
+	if len(clause) > 1 {
+		w1 = 1
+	}
+	return s.registerClause(clause, 0, w1)
+}
+
+// dedupeLiterals returns clause with repeated literals collapsed to a single
+// occurrence, preserving the order of first appearance. Without this, a
+// clause like {2, 2} would be registered as an ordinary two-watch clause
+// instead of being recognized as the unit clause it actually is.
+func dedupeLiterals(clause Clause) Clause {
+	seen := make(map[Literal]bool, len(clause))
+	deduped := make(Clause, 0, len(clause))
+	for _, literal := range clause {
+		if seen[literal] {
+			continue
+		}
+		seen[literal] = true
+		deduped = append(deduped, literal)
+	}
+	return deduped
+}
+
+// ImportClause adds a clause learned by a peer solver (see SolvePortfolio)
+// to the database. It picks watches among the literals not already
+// falsified by the current assignment; if every literal is already false,
+// the clause is dropped rather than tracked as a standing conflict.
+func (s *Solver) ImportClause(clause Clause) {
+	s.addUnderAssignment(clause)
+}
+
+// addUnderAssignment registers clause, watching two of its literals not
+// currently falsified (or fewer, if the clause doesn't have two). It returns
+// the clause's index and whether every literal is already falsified, which
+// is an immediate conflict rather than something propagate can act on. If
+// the clause turns out to be unit under the current assignment, its
+// remaining literal is asserted immediately.
+func (s *Solver) addUnderAssignment(clause Clause) (idx int, conflict bool) {
+	clause = dedupeLiterals(clause)
+
+	var nonFalse []int
+	for i, literal := range clause {
+		if !s.isFalse(literal) {
+			nonFalse = append(nonFalse, i)
+			if len(nonFalse) == 2 {
+				break
+			}
+		}
+	}
+	if len(nonFalse) == 0 {
+		return -1, true
+	}
+
+	w1 := nonFalse[0]
+	if len(nonFalse) > 1 {
+		w1 = nonFalse[1]
+	}
+	idx = s.registerClause(clause, nonFalse[0], w1)
+
+	if nonFalse[0] == w1 {
+		if asserted := clause[nonFalse[0]]; !s.isTrue(asserted) {
+			s.assign(asserted, idx)
+		}
+	}
+	return idx, false
+}
+
+func varOf(literal Literal) Literal {
+	if literal < 0 {
+		return -literal
+	}
+	return literal
+}
+
+func (s *Solver) isTrue(literal Literal) bool {
+	value, ok := s.assignment[varOf(literal)]
+	if !ok {
+		return false
+	}
+	return value == (literal > 0)
+}
+
+func (s *Solver) isFalse(literal Literal) bool {
+	value, ok := s.assignment[varOf(literal)]
+	if !ok {
+		return false
+	}
+	return value != (literal > 0)
+}
+
+// assign records literal as true at the current decision level, with reason
+// as its antecedent clause (noReason for a decision).
+func (s *Solver) assign(literal Literal, reason int) {
+	v := varOf(literal)
+	s.assignment[v] = literal > 0
+	s.varLevel[v] = s.decisionLvl
+	s.varReason[v] = reason
+	s.phase[v] = literal > 0
+	s.trail = append(s.trail, trailEntry{literal, s.decisionLvl})
+	s.queue = append(s.queue, literal)
+
+	if s.brancher != nil {
+		s.brancher.Assigned(v)
+	}
+}
+
+// decide starts a new decision level and assigns literal as its decision
+// literal.
+func (s *Solver) decide(literal Literal) {
+	s.decisionLvl++
+	s.assign(literal, noReason)
+}
+
+// undoTo unassigns every literal pushed onto the trail at or after pos,
+// restoring the decision level that was active at that point. If pos is
+// already the end of the trail, it's a no-op: in particular it leaves the
+// propagation queue alone, since callers may hold a pending literal there
+// that hasn't been through propagate() yet.
+func (s *Solver) undoTo(pos int) {
+	if pos >= len(s.trail) {
+		return
+	}
+
+	for i := len(s.trail) - 1; i >= pos; i-- {
+		v := varOf(s.trail[i].literal)
+		delete(s.assignment, v)
+		delete(s.varLevel, v)
+		delete(s.varReason, v)
+		if s.brancher != nil {
+			s.brancher.Unassigned(v)
+		}
+	}
+
+	if pos == 0 {
+		s.decisionLvl = 0
+	} else {
+		s.decisionLvl = s.trail[pos-1].level
+	}
+	s.trail = s.trail[:pos]
+	s.queue = s.queue[:0]
+}
+
+// positionAtLevel returns the first trail index assigned after level,
+// i.e. where undoTo must truncate to backjump to level.
+func (s *Solver) positionAtLevel(level int) int {
+	for i, entry := range s.trail {
+		if entry.level > level {
+			return i
+		}
+	}
+	return len(s.trail)
+}
+
+// propagate drains the propagation queue, updating watches as it goes. It
+// returns the index of the clause that conflicted, or -1 once the queue is
+// exhausted without conflict.
+func (s *Solver) propagate() int {
+	for len(s.queue) > 0 {
+		literal := s.queue[0]
+		s.queue = s.queue[1:]
+
+		falsified := -literal
+		watchers := s.watchers[falsified]
+
+		kept := watchers[:0]
+		for idx := 0; idx < len(watchers); idx++ {
+			clauseIdx := watchers[idx]
+			if s.deleted[clauseIdx] {
+				continue
+			}
+			clause := s.clauses[clauseIdx]
+			w := s.watchIdx[clauseIdx]
+
+			falsifiedSlot, otherSlot := 0, 1
+			if clause[w[1]] == falsified {
+				falsifiedSlot, otherSlot = 1, 0
+			}
+
+			moved := false
+			for k, candidate := range clause {
+				if k == w[0] || k == w[1] {
+					continue
+				}
+				if s.isFalse(candidate) {
+					continue
+				}
+				w[falsifiedSlot] = k
+				s.watchIdx[clauseIdx] = w
+				s.watchers[candidate] = append(s.watchers[candidate], clauseIdx)
+				moved = true
+				break
+			}
+			if moved {
+				continue
+			}
+
+			kept = append(kept, clauseIdx)
+			other := clause[w[otherSlot]]
+			if s.isTrue(other) {
+				continue
+			}
+			if s.isFalse(other) {
+				kept = append(kept, watchers[idx+1:]...)
+				s.watchers[falsified] = kept
+				s.queue = s.queue[:0]
+				return clauseIdx
+			}
+			s.assign(other, clauseIdx)
+		}
+		s.watchers[falsified] = kept
+	}
+
+	return -1
+}
+
+// search performs the branching half of DPLL: pick an unassigned literal,
+// try it true then false, backtracking the trail between attempts.
+func (s *Solver) search() (bool, Assignment) {
+	literal, ok := s.pickLiteral()
+	if !ok {
+		return true, maps.Clone(s.assignment)
+	}
+
+	pos := len(s.trail)
+
+	s.decide(literal)
+	if s.propagate() == -1 {
+		if satisfied, result := s.search(); satisfied {
+			return true, result
+		}
+	}
+	s.undoTo(pos)
+
+	s.decide(-literal)
+	if s.propagate() == -1 {
+		if satisfied, result := s.search(); satisfied {
+			return true, result
+		}
+	}
+	s.undoTo(pos)
+
+	return false, nil
+}
@@ -0,0 +1,58 @@
+package dpll
+
+import "testing"
+
+func TestSolveSatisfiable(t *testing.T) {
+	formula := Formula{{1, 2}, {-1, 2}, {1, -2}}
+
+	sat, assignment := Solve(formula, make(Assignment))
+	if !sat {
+		t.Fatalf("Solve() = unsat, want sat")
+	}
+	if err := VerifyAssignment(formula, assignment); err != nil {
+		t.Fatalf("VerifyAssignment() = %v, want nil", err)
+	}
+}
+
+func TestSolveUnsatisfiable(t *testing.T) {
+	formula := Formula{{1}, {-1}}
+
+	sat, _ := Solve(formula, make(Assignment))
+	if sat {
+		t.Fatalf("Solve() = sat, want unsat")
+	}
+}
+
+func TestNewSolverAssertsUnitClausesAtLoad(t *testing.T) {
+	formula := Formula{{1}, {-2}, {1, 2, 3}}
+
+	s := NewSolver(formula)
+	if !s.isTrue(1) {
+		t.Errorf("unit clause {1} not asserted at load time")
+	}
+	if !s.isTrue(-2) {
+		t.Errorf("unit clause {-2} not asserted at load time")
+	}
+}
+
+func TestNewSolverDetectsConflictingUnitsAtLoad(t *testing.T) {
+	formula := Formula{{1}, {-1}}
+
+	s := NewSolver(formula)
+	if !s.loadConflict {
+		t.Errorf("loadConflict = false, want true for contradictory unit clauses")
+	}
+}
+
+func TestNewSolverDedupesRepeatedLiteralToUnit(t *testing.T) {
+	// {2, 2} is really the unit clause {2}; without deduping, the two
+	// watched positions are two different slots holding the same literal,
+	// so the clause looks like an ordinary two-literal clause rather than a
+	// unit.
+	formula := Formula{{2, 2}, {-2}}
+
+	s := NewSolver(formula)
+	if !s.loadConflict {
+		t.Errorf("loadConflict = false, want true: {2,2} and {-2} contradict")
+	}
+}
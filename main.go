@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/Aki0x137/concurrent-sat-solver-go/dimacs"
 	"github.com/Aki0x137/concurrent-sat-solver-go/dpll"
 )
 
@@ -264,15 +266,9 @@ import (
 // 	}
 // }
 
-func main() {
-	file, err := os.Open("input.csv")
-
-	if err != nil {
-		log.Fatal("Error opening input file.\n Exiting...")
-		return
-	}
-	defer file.Close()
-
+// readCSVFormula reads the legacy CSV-like format: one clause per line,
+// literals separated by commas.
+func readCSVFormula(file *os.File) dpll.Formula {
 	var formula dpll.Formula
 
 	scanner := bufio.NewScanner(file)
@@ -297,6 +293,34 @@ func main() {
 		log.Fatal("Error while reading input. \nExiting...")
 	}
 
+	return formula
+}
+
+func main() {
+	inputPath := "input.csv"
+	if len(os.Args) > 1 {
+		inputPath = os.Args[1]
+	}
+
+	file, err := os.Open(inputPath)
+
+	if err != nil {
+		log.Fatal("Error opening input file.\n Exiting...")
+		return
+	}
+	defer file.Close()
+
+	var formula dpll.Formula
+
+	if strings.EqualFold(filepath.Ext(inputPath), ".cnf") {
+		formula, err = dimacs.Parse(file)
+		if err != nil {
+			log.Fatalf("Error while reading DIMACS input.\n%s\nExiting...", err)
+		}
+	} else {
+		formula = readCSVFormula(file)
+	}
+
 	sat, final_assignments := dpll.Solve(formula, make(dpll.Assignment))
 
 	if sat {